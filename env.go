@@ -2,15 +2,12 @@ package railway
 
 import (
 	"errors"
-	"fmt"
 	"os"
 	"strconv"
 )
 
 var projectID = os.Getenv("RAILWAY_PROJECT_ID")
 
-var ErrNotRailway = errors.New("not running on Railway")
-
 // Env is the Railway-provided environment variables.  See https://docs.railway.com/reference/variables#railway-provided-variables for more details.
 type Env struct {
 	// The public service or customer domain, of the form example.up.railway.app
@@ -122,52 +119,68 @@ func MustLoad() Env {
 	return Must(Load())
 }
 
+// Load reads the Railway-provided environment variables into an Env. It
+// returns ErrNotRailway if the process isn't running on Railway, or the
+// first malformed variable it encounters as a *EnvError. It does not
+// validate that Railway's other identity variables (RAILWAY_ENVIRONMENT_ID,
+// RAILWAY_SERVICE_ID, RAILWAY_REPLICA_ID) are present, so it keeps
+// working unchanged for callers who only need RAILWAY_PROJECT_ID, e.g. in
+// a minimal Railway-like sandbox. Use LoadStrict or LoadPartial to also
+// surface those as KindMissing and to see every malformed variable at
+// once instead of just the first.
 func Load() (Env, error) {
-	if !IsRailway() {
-		return Env{}, ErrNotRailway
-	}
-
-	tcpProxyPort, err := getEnvInt("RAILWAY_TCP_PROXY_PORT", 0)
-	if err != nil {
-		return Env{}, err
-	}
-
-	tcpApplicationPort, err := getEnvInt("RAILWAY_TCP_APPLICATION_PORT", 0)
-	if err != nil {
-		return Env{}, err
+	env, errs := loadEnv(false)
+	if len(errs) > 0 {
+		return Env{}, errs[0]
 	}
+	return env, nil
+}
 
-	deploymentOverlapSeconds, err := getEnvInt("RAILWAY_DEPLOYMENT_OVERLAP_SECONDS", 0)
-	if err != nil {
-		return Env{}, err
+// LoadStrict is like Load, but instead of stopping at the first
+// malformed variable it validates all of them and returns every failure
+// as a MultiError. It additionally requires RAILWAY_ENVIRONMENT_ID,
+// RAILWAY_SERVICE_ID, and RAILWAY_REPLICA_ID to be set, surfacing each as
+// a KindMissing EnvError if not; Load does not apply this stricter check.
+func LoadStrict() (Env, error) {
+	env, errs := loadEnv(true)
+	if len(errs) > 0 {
+		return Env{}, MultiError(errs)
 	}
+	return env, nil
+}
 
-	healthcheckTimeoutSec, err := getEnvInt("RAILWAY_HEALTHCHECK_TIMEOUT_SEC", 0)
-	if err != nil {
-		return Env{}, err
+// LoadPartial is like LoadStrict, including its KindMissing check on
+// RAILWAY_ENVIRONMENT_ID/SERVICE_ID/REPLICA_ID, but it returns the
+// best-effort Env alongside the aggregated errors instead of a zero
+// value, so a deploy with one bad numeric override doesn't crash-loop
+// before an operator can see which variables failed. Fields that failed
+// to parse are left at their default.
+func LoadPartial() (Env, error) {
+	env, errs := loadEnv(true)
+	if len(errs) == 0 {
+		return env, nil
 	}
+	return env, MultiError(errs)
+}
 
-	deploymentDrainingSeconds, err := getEnvInt("RAILWAY_DEPLOYMENT_DRAINING_SECONDS", 0)
-	if err != nil {
-		return Env{}, err
+// loadEnv reads every Railway environment variable into an Env. When
+// strict is true it additionally requires the identity variables beyond
+// RAILWAY_PROJECT_ID to be set, returning a KindMissing error for any
+// that are empty; Load runs with strict false to preserve its existing,
+// looser contract.
+func loadEnv(strict bool) (Env, []*EnvError) {
+	if !IsRailway() {
+		return Env{}, []*EnvError{ErrNotRailway.(*EnvError)}
 	}
 
-	runUID, err := getEnvInt("RAILWAY_RUN_UID", 0)
-	if err != nil {
-		return Env{}, err
-	}
+	var errs []*EnvError
 
-	shmSizeBytes, err := getEnvInt64("RAILWAY_SHM_SIZE_BYTES", 0)
-	if err != nil {
-		return Env{}, err
-	}
-
-	return Env{
+	env := Env{
 		PublicDomain:              os.Getenv("RAILWAY_PUBLIC_DOMAIN"),
 		PrivateDomain:             os.Getenv("RAILWAY_PRIVATE_DOMAIN"),
 		TCPProxyDomain:            os.Getenv("RAILWAY_TCP_PROXY_DOMAIN"),
-		TCPProxyPort:              tcpProxyPort,
-		TCPApplicationPort:        tcpApplicationPort,
+		TCPProxyPort:              getEnvInt("RAILWAY_TCP_PROXY_PORT", 0, &errs),
+		TCPApplicationPort:        getEnvInt("RAILWAY_TCP_APPLICATION_PORT", 0, &errs),
 		ProjectName:               os.Getenv("RAILWAY_PROJECT_NAME"),
 		ProjectID:                 projectID,
 		EnvironmentName:           os.Getenv("RAILWAY_ENVIRONMENT_NAME"),
@@ -186,35 +199,86 @@ func Load() (Env, error) {
 		GitRepoName:               os.Getenv("RAILWAY_GIT_REPO_NAME"),
 		GitRepoOwner:              os.Getenv("RAILWAY_GIT_REPO_OWNER"),
 		GitCommitMessage:          os.Getenv("RAILWAY_GIT_COMMIT_MESSAGE"),
-		DeploymentOverlapSeconds:  deploymentOverlapSeconds,
+		DeploymentOverlapSeconds:  getEnvInt("RAILWAY_DEPLOYMENT_OVERLAP_SECONDS", 0, &errs),
 		DockerfilePath:            os.Getenv("RAILWAY_DOCKERFILE_PATH"),
 		NixpacksConfigFile:        os.Getenv("NIXPACKS_CONFIG_FILE"),
 		NixpacksVersion:           os.Getenv("NIXPACKS_VERSION"),
-		HealthcheckTimeoutSec:     healthcheckTimeoutSec,
-		DeploymentDrainingSeconds: deploymentDrainingSeconds,
-		RunUID:                    runUID,
-		SHMSizeBytes:              shmSizeBytes,
-	}, nil
-}
+		HealthcheckTimeoutSec:     getEnvInt("RAILWAY_HEALTHCHECK_TIMEOUT_SEC", 0, &errs),
+		DeploymentDrainingSeconds: getEnvInt("RAILWAY_DEPLOYMENT_DRAINING_SECONDS", 0, &errs),
+		RunUID:                    getEnvInt("RAILWAY_RUN_UID", 0, &errs),
+		SHMSizeBytes:              getEnvInt64("RAILWAY_SHM_SIZE_BYTES", 0, &errs),
+	}
 
-func getEnvInt(env string, defaultValue int) (int, error) {
-	if value := os.Getenv(env); value != "" {
-		res, err := strconv.Atoi(value)
-		if err != nil {
-			return 0, fmt.Errorf("invalid %s: %w", env, err)
+	if strict {
+		for _, required := range []struct {
+			name  string
+			value string
+		}{
+			{"RAILWAY_ENVIRONMENT_ID", env.EnvironmentID},
+			{"RAILWAY_SERVICE_ID", env.ServiceID},
+			{"RAILWAY_REPLICA_ID", env.ReplicaID},
+		} {
+			if required.value == "" {
+				errs = append(errs, &EnvError{
+					Var:  required.name,
+					Kind: KindMissing,
+				})
+			}
 		}
-		return res, nil
 	}
-	return defaultValue, nil
+
+	if env.RunUID < 0 {
+		errs = append(errs, &EnvError{
+			Var:  "RAILWAY_RUN_UID",
+			Raw:  strconv.Itoa(env.RunUID),
+			Kind: KindOutOfRange,
+			Err:  errors.New("must be >= 0"),
+		})
+	}
+
+	if raw := os.Getenv("RAILWAY_SHM_SIZE_BYTES"); raw != "" && env.SHMSizeBytes <= 0 && !hasVarError(errs, "RAILWAY_SHM_SIZE_BYTES") {
+		errs = append(errs, &EnvError{
+			Var:  "RAILWAY_SHM_SIZE_BYTES",
+			Raw:  raw,
+			Kind: KindOutOfRange,
+			Err:  errors.New("must be > 0 when set"),
+		})
+	}
+
+	return env, errs
 }
 
-func getEnvInt64(env string, defaultValue int64) (int64, error) {
-	if value := os.Getenv(env); value != "" {
-		res, err := strconv.ParseInt(value, 10, 64)
-		if err != nil {
-			return 0, fmt.Errorf("invalid %s: %w", env, err)
+func hasVarError(errs []*EnvError, name string) bool {
+	for _, err := range errs {
+		if err.Var == name {
+			return true
 		}
-		return res, nil
 	}
-	return defaultValue, nil
+	return false
+}
+
+func getEnvInt(name string, defaultValue int, errs *[]*EnvError) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	res, err := strconv.Atoi(value)
+	if err != nil {
+		*errs = append(*errs, &EnvError{Var: name, Raw: value, Kind: KindInvalidInt, Err: err})
+		return defaultValue
+	}
+	return res
+}
+
+func getEnvInt64(name string, defaultValue int64, errs *[]*EnvError) int64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	res, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		*errs = append(*errs, &EnvError{Var: name, Raw: value, Kind: KindInvalidInt, Err: err})
+		return defaultValue
+	}
+	return res
 }
@@ -0,0 +1,140 @@
+package railway
+
+import (
+	"errors"
+	"testing"
+)
+
+// withRailwayIdentity sets projectID and the other identity env vars
+// loadEnv requires so tests can exercise the parsing/validation path
+// without every case tripping KindMissing.
+func withRailwayIdentity(t *testing.T) {
+	t.Helper()
+	prevProjectID := projectID
+	projectID = "test-project"
+	t.Cleanup(func() { projectID = prevProjectID })
+
+	t.Setenv("RAILWAY_ENVIRONMENT_ID", "test-environment")
+	t.Setenv("RAILWAY_SERVICE_ID", "test-service")
+	t.Setenv("RAILWAY_REPLICA_ID", "test-replica")
+}
+
+func TestLoadReturnsErrNotRailway(t *testing.T) {
+	prevProjectID := projectID
+	projectID = ""
+	t.Cleanup(func() { projectID = prevProjectID })
+
+	_, err := Load()
+	if !errors.Is(err, ErrNotRailway) {
+		t.Fatalf("got %v, want ErrNotRailway", err)
+	}
+}
+
+func TestLoadStrictAggregatesEveryError(t *testing.T) {
+	withRailwayIdentity(t)
+	t.Setenv("RAILWAY_TCP_PROXY_PORT", "not-a-number")
+	t.Setenv("RAILWAY_RUN_UID", "-1")
+
+	_, err := LoadStrict()
+	var multi MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("got %T, want MultiError", err)
+	}
+	if len(multi) != 2 {
+		t.Fatalf("got %d errors, want 2: %v", len(multi), multi)
+	}
+
+	var sawInvalidInt, sawOutOfRange bool
+	for _, e := range multi {
+		switch {
+		case e.Var == "RAILWAY_TCP_PROXY_PORT" && e.Kind == KindInvalidInt:
+			sawInvalidInt = true
+		case e.Var == "RAILWAY_RUN_UID" && e.Kind == KindOutOfRange:
+			sawOutOfRange = true
+		}
+	}
+	if !sawInvalidInt || !sawOutOfRange {
+		t.Fatalf("missing expected errors in %v", multi)
+	}
+	if !IsInvalid(err) {
+		t.Fatal("IsInvalid(err) = false, want true")
+	}
+}
+
+func TestLoadPartialReturnsBestEffortEnv(t *testing.T) {
+	withRailwayIdentity(t)
+	t.Setenv("RAILWAY_TCP_PROXY_PORT", "not-a-number")
+	t.Setenv("RAILWAY_SERVICE_NAME", "web")
+
+	env, err := LoadPartial()
+	if err == nil {
+		t.Fatal("expected a non-nil error for the malformed port")
+	}
+	if env.ServiceName != "web" {
+		t.Fatalf("ServiceName = %q, want %q", env.ServiceName, "web")
+	}
+	if env.TCPProxyPort != 0 {
+		t.Fatalf("TCPProxyPort = %d, want 0 default for the malformed value", env.TCPProxyPort)
+	}
+}
+
+func TestLoadPartialNoErrorsWhenClean(t *testing.T) {
+	withRailwayIdentity(t)
+
+	env, err := LoadPartial()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env.ProjectID != "test-project" {
+		t.Fatalf("ProjectID = %q, want %q", env.ProjectID, "test-project")
+	}
+}
+
+func TestLoadDoesNotCheckOtherIdentityVars(t *testing.T) {
+	// Load's contract predates KindMissing: it only ever required
+	// RAILWAY_PROJECT_ID, so callers relying on that (tests, minimal
+	// Railway-like sandboxes) must keep working unchanged.
+	prevProjectID := projectID
+	projectID = "test-project"
+	t.Cleanup(func() { projectID = prevProjectID })
+	// RAILWAY_ENVIRONMENT_ID/SERVICE_ID/REPLICA_ID deliberately left unset.
+
+	env, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env.ProjectID != "test-project" {
+		t.Fatalf("ProjectID = %q, want %q", env.ProjectID, "test-project")
+	}
+}
+
+func TestLoadStrictReportsMissingIdentityVars(t *testing.T) {
+	prevProjectID := projectID
+	projectID = "test-project"
+	t.Cleanup(func() { projectID = prevProjectID })
+	// RAILWAY_ENVIRONMENT_ID/SERVICE_ID/REPLICA_ID deliberately left unset.
+
+	_, err := LoadStrict()
+	if !IsMissing(err) {
+		t.Fatalf("got %v, want a KindMissing error", err)
+	}
+
+	var multi MultiError
+	if !errors.As(err, &multi) || len(multi) != 3 {
+		t.Fatalf("got %v, want 3 KindMissing errors (environment, service, replica IDs)", err)
+	}
+}
+
+func TestLoadPartialReportsMissingIdentityVars(t *testing.T) {
+	prevProjectID := projectID
+	projectID = "test-project"
+	t.Cleanup(func() { projectID = prevProjectID })
+
+	env, err := LoadPartial()
+	if !IsMissing(err) {
+		t.Fatalf("got %v, want a KindMissing error", err)
+	}
+	if env.ProjectID != "test-project" {
+		t.Fatalf("ProjectID = %q, want %q", env.ProjectID, "test-project")
+	}
+}
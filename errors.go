@@ -0,0 +1,114 @@
+package railway
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorKind classifies why an EnvError occurred.
+type ErrorKind int
+
+const (
+	// KindNotRailway means the process is not running on Railway at all.
+	KindNotRailway ErrorKind = iota
+	// KindMissing means the process is running on Railway but a variable
+	// Railway is expected to always provide came back empty. Only
+	// LoadStrict and LoadPartial check for this; Load does not, to keep
+	// its existing, looser contract for callers who only need
+	// RAILWAY_PROJECT_ID.
+	KindMissing
+	// KindInvalidInt means a numeric variable could not be parsed.
+	KindInvalidInt
+	// KindOutOfRange means a variable parsed fine but failed validation,
+	// e.g. a negative RunUID.
+	KindOutOfRange
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case KindNotRailway:
+		return "not_railway"
+	case KindMissing:
+		return "missing"
+	case KindInvalidInt:
+		return "invalid_int"
+	case KindOutOfRange:
+		return "out_of_range"
+	default:
+		return "unknown"
+	}
+}
+
+// EnvError describes a problem with a single Railway environment
+// variable encountered while loading an Env.
+type EnvError struct {
+	// Var is the name of the offending environment variable, empty for
+	// KindNotRailway.
+	Var string
+	// Raw is the variable's raw string value, empty if it was unset.
+	Raw string
+	// Kind classifies the failure.
+	Kind ErrorKind
+	// Err is the underlying error, if any (e.g. a strconv error).
+	Err error
+}
+
+func (e *EnvError) Error() string {
+	switch e.Kind {
+	case KindNotRailway:
+		return "not running on Railway"
+	case KindMissing:
+		return fmt.Sprintf("%s: expected but missing", e.Var)
+	case KindInvalidInt:
+		return fmt.Sprintf("%s: invalid integer %q: %v", e.Var, e.Raw, e.Err)
+	case KindOutOfRange:
+		return fmt.Sprintf("%s: value %q out of range: %v", e.Var, e.Raw, e.Err)
+	default:
+		return fmt.Sprintf("%s: %v", e.Var, e.Err)
+	}
+}
+
+func (e *EnvError) Unwrap() error {
+	return e.Err
+}
+
+// ErrNotRailway is returned by Load, LoadStrict, and LoadPartial when the
+// process is not running on Railway. It is a *EnvError with Kind
+// KindNotRailway; compare against it with errors.Is.
+var ErrNotRailway error = &EnvError{Kind: KindNotRailway}
+
+// IsInvalid reports whether err is, or wraps, an EnvError for a variable
+// that was set but failed to parse or validate.
+func IsInvalid(err error) bool {
+	var envErr *EnvError
+	return errors.As(err, &envErr) && (envErr.Kind == KindInvalidInt || envErr.Kind == KindOutOfRange)
+}
+
+// IsMissing reports whether err is, or wraps, an EnvError for a variable
+// Railway is expected to always set that came back empty.
+func IsMissing(err error) bool {
+	var envErr *EnvError
+	return errors.As(err, &envErr) && envErr.Kind == KindMissing
+}
+
+// MultiError aggregates every EnvError found by LoadStrict or
+// LoadPartial.
+type MultiError []*EnvError
+
+func (m MultiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap lets errors.Is and errors.As inspect each aggregated EnvError.
+func (m MultiError) Unwrap() []error {
+	errs := make([]error, len(m))
+	for i, err := range m {
+		errs[i] = err
+	}
+	return errs
+}
@@ -0,0 +1,193 @@
+package railway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// CheckResult is the outcome of a single named health check.
+type CheckResult struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Error   string `json:"error,omitempty"`
+	Latency string `json:"latency"`
+}
+
+// HealthReport is the JSON payload returned by Health.Readiness.
+type HealthReport struct {
+	OK     bool          `json:"ok"`
+	Checks []CheckResult `json:"checks,omitempty"`
+}
+
+// Health runs liveness and readiness checks for a service, honoring the
+// per-check deadline implied by Env.HealthcheckTimeoutSec.
+type Health struct {
+	timeout time.Duration
+	drainer *Drainer
+
+	mu     sync.Mutex
+	names  []string
+	checks map[string]func(ctx context.Context) error
+}
+
+// NewHealth builds a Health from env's healthcheck timeout.
+func NewHealth(env Env) *Health {
+	return &Health{
+		timeout: time.Duration(env.HealthcheckTimeoutSec) * time.Second,
+		checks:  make(map[string]func(ctx context.Context) error),
+	}
+}
+
+// UseDrainer ties readiness to d, so Readiness starts reporting failing
+// as soon as d begins draining rather than waiting for the process to
+// exit.
+func (h *Health) UseDrainer(d *Drainer) {
+	h.drainer = d
+}
+
+// Register adds a named check that Readiness runs on every request, such
+// as a database ping, a downstream service call, or a write to
+// Env.VolumeMountPath. Registering under an existing name replaces it.
+func (h *Health) Register(name string, check func(ctx context.Context) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, exists := h.checks[name]; !exists {
+		h.names = append(h.names, name)
+	}
+	h.checks[name] = check
+}
+
+// Liveness returns a handler that never runs the registered checks. If
+// next is nil it answers 200 as long as the process is up; otherwise it
+// delegates to next, which writes the response. Use it for a liveness
+// probe that should only restart the container on deadlock or crash;
+// pass nil if the probe has no downstream handler to chain to.
+func (h *Health) Liveness(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if next != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// Readiness returns a handler that runs every registered check, each
+// bounded by an even share of Env.HealthcheckTimeoutSec. If every check
+// passes and the Drainer passed to UseDrainer (if any) has not begun
+// shutting down, it delegates to next (if non-nil); otherwise, or if
+// next is nil, it reports the aggregate result as JSON, answering 503 if
+// any check failed or if that Drainer has begun shutting down.
+func (h *Health) Readiness(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := h.run(r.Context())
+
+		if report.OK && next != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if !report.OK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+func (h *Health) run(ctx context.Context) HealthReport {
+	h.mu.Lock()
+	names := append([]string(nil), h.names...)
+	checks := make(map[string]func(ctx context.Context) error, len(h.checks))
+	for name, check := range h.checks {
+		checks[name] = check
+	}
+	h.mu.Unlock()
+
+	report := HealthReport{OK: true}
+	if h.drainer != nil && !h.drainer.ready.Load() {
+		report.OK = false
+	}
+
+	var perCheck time.Duration
+	if h.timeout > 0 && len(names) > 0 {
+		perCheck = h.timeout / time.Duration(len(names))
+	}
+
+	for _, name := range names {
+		start := time.Now()
+
+		checkCtx := ctx
+		var cancel context.CancelFunc
+		if perCheck > 0 {
+			checkCtx, cancel = context.WithTimeout(ctx, perCheck)
+		}
+		err := checks[name](checkCtx)
+		if cancel != nil {
+			cancel()
+		}
+
+		result := CheckResult{Name: name, OK: err == nil, Latency: time.Since(start).String()}
+		if err != nil {
+			result.Error = err.Error()
+			report.OK = false
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	return report
+}
+
+// VolumeCheck returns a Health check that verifies Env.VolumeMountPath,
+// if set, is writable. It is a no-op when no volume is attached.
+func VolumeCheck(env Env) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if env.VolumeMountPath == "" {
+			return nil
+		}
+		f, err := os.CreateTemp(env.VolumeMountPath, ".railway-health-*")
+		if err != nil {
+			return fmt.Errorf("volume %s not writable: %w", env.VolumeMountPath, err)
+		}
+		name := f.Name()
+		f.Close()
+		return os.Remove(name)
+	}
+}
+
+// HealthCheckMain hits the readiness endpoint at url (typically
+// http://localhost:<port>/health/ready) and exits 0 if it answers 200 or
+// 1 otherwise, matching the exit-code contract Docker's HEALTHCHECK
+// instruction expects. The request is bounded by env.HealthcheckTimeoutSec,
+// falling back to 5s when it's unset, so the healthcheck command honors
+// the same deadline Railway declared. It is meant to be invoked from the
+// same binary under a dedicated flag or subcommand, e.g.:
+//
+//	if len(os.Args) > 1 && os.Args[1] == "healthcheck" {
+//		railway.HealthCheckMain(env, "http://localhost:8080/health/ready")
+//	}
+func HealthCheckMain(env Env, url string) {
+	timeout := 5 * time.Second
+	if env.HealthcheckTimeoutSec > 0 {
+		timeout = time.Duration(env.HealthcheckTimeoutSec) * time.Second
+	}
+
+	client := http.Client{Timeout: timeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	ok := resp.StatusCode == http.StatusOK
+	resp.Body.Close()
+
+	if !ok {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
@@ -0,0 +1,134 @@
+package railway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHealthLivenessAlwaysOK(t *testing.T) {
+	h := NewHealth(Env{})
+	rec := httptest.NewRecorder()
+	h.Liveness(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200", rec.Code)
+	}
+}
+
+func TestHealthReadinessSplitsTimeoutAcrossChecks(t *testing.T) {
+	h := NewHealth(Env{HealthcheckTimeoutSec: 2})
+
+	var deadlines []time.Duration
+	for _, name := range []string{"a", "b"} {
+		h.Register(name, func(ctx context.Context) error {
+			dl, ok := ctx.Deadline()
+			if !ok {
+				t.Error("expected per-check context to carry a deadline")
+				return nil
+			}
+			deadlines = append(deadlines, time.Until(dl))
+			return nil
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	h.Readiness(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200", rec.Code)
+	}
+	if len(deadlines) != 2 {
+		t.Fatalf("expected 2 checks to run, got %d", len(deadlines))
+	}
+	for _, d := range deadlines {
+		if d <= 0 || d > time.Second {
+			t.Errorf("per-check deadline %v not within the expected ~1s share of a 2s budget", d)
+		}
+	}
+}
+
+func TestHealthReadinessFailsOnCheckError(t *testing.T) {
+	h := NewHealth(Env{})
+	h.Register("broken", func(ctx context.Context) error {
+		return context.DeadlineExceeded
+	})
+
+	rec := httptest.NewRecorder()
+	h.Readiness(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("got %d, want 503", rec.Code)
+	}
+
+	var report HealthReport
+	if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+		t.Fatalf("decoding report: %v", err)
+	}
+	if report.OK {
+		t.Fatal("report.OK = true, want false")
+	}
+	if len(report.Checks) != 1 || report.Checks[0].Error == "" {
+		t.Fatalf("expected one failing check with an error message, got %+v", report.Checks)
+	}
+}
+
+func TestHealthLivenessDelegatesToNext(t *testing.T) {
+	h := NewHealth(Env{})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	rec := httptest.NewRecorder()
+	h.Liveness(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusTeapot {
+		t.Fatalf("got %d, want next's 418", rec.Code)
+	}
+}
+
+func TestHealthReadinessDelegatesToNextOnlyWhenOK(t *testing.T) {
+	h := NewHealth(Env{})
+	var nextCalled bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nextCalled = true
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	rec := httptest.NewRecorder()
+	h.Readiness(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !nextCalled || rec.Code != http.StatusTeapot {
+		t.Fatalf("expected next to be called when ready, got called=%v code=%d", nextCalled, rec.Code)
+	}
+
+	nextCalled = false
+	h.Register("broken", func(ctx context.Context) error { return context.DeadlineExceeded })
+
+	rec = httptest.NewRecorder()
+	h.Readiness(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if nextCalled {
+		t.Fatal("next must not be called when a check fails")
+	}
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("got %d, want 503", rec.Code)
+	}
+}
+
+func TestHealthReadinessReflectsDrainerState(t *testing.T) {
+	d := NewDrainer(Env{})
+	h := NewHealth(Env{})
+	h.UseDrainer(d)
+
+	rec := httptest.NewRecorder()
+	h.Readiness(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("before drain: got %d, want 200", rec.Code)
+	}
+
+	d.drain()
+
+	rec = httptest.NewRecorder()
+	h.Readiness(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("after drain: got %d, want 503", rec.Code)
+	}
+}
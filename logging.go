@@ -0,0 +1,65 @@
+package railway
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// LogAttrs returns structured log attributes describing env's deployment
+// and, if ctx carries Headers, the current request, suitable for slog
+// calls (e.g. logger.LogAttrs(ctx, slog.LevelInfo, "msg",
+// railway.LogAttrs(ctx, env)...)). env is typically loaded once at
+// startup via railway.Load, since replica/service/deployment identity
+// doesn't vary per request.
+func LogAttrs(ctx context.Context, env Env) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("railway.replica.id", env.ReplicaID),
+		slog.String("railway.replica.region", env.ReplicaRegion),
+		slog.String("railway.service.name", env.ServiceName),
+		slog.String("railway.deployment.id", env.DeploymentID),
+	}
+
+	headers, ok := HeadersFromContext(ctx)
+	if !ok {
+		return attrs
+	}
+
+	attrs = append(attrs,
+		slog.String("railway.request_id", headers.RailwayRequestID),
+		slog.String("railway.edge", headers.RailwayEdge),
+	)
+	if !headers.RequestStart.IsZero() {
+		attrs = append(attrs, slog.Duration("railway.request_start_latency", time.Since(headers.RequestStart)))
+	}
+
+	return attrs
+}
+
+// HCLogFields returns the same Railway deployment and request context as
+// alternating key/value pairs, matching the variadic signature
+// hclog.Logger methods expect (e.g. logger.Info("msg",
+// railway.HCLogFields(ctx, env)...)).
+func HCLogFields(ctx context.Context, env Env) []interface{} {
+	fields := []interface{}{
+		"railway.replica.id", env.ReplicaID,
+		"railway.replica.region", env.ReplicaRegion,
+		"railway.service.name", env.ServiceName,
+		"railway.deployment.id", env.DeploymentID,
+	}
+
+	headers, ok := HeadersFromContext(ctx)
+	if !ok {
+		return fields
+	}
+
+	fields = append(fields,
+		"railway.request_id", headers.RailwayRequestID,
+		"railway.edge", headers.RailwayEdge,
+	)
+	if !headers.RequestStart.IsZero() {
+		fields = append(fields, "railway.request_start_latency", time.Since(headers.RequestStart))
+	}
+
+	return fields
+}
@@ -0,0 +1,87 @@
+package railway
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func withHeaders(ctx context.Context, h Headers) context.Context {
+	return context.WithValue(ctx, headersKey, h)
+}
+
+func TestLogAttrsWithoutHeaders(t *testing.T) {
+	env := Env{ReplicaID: "replica-1", ReplicaRegion: "us-west2", ServiceName: "web", DeploymentID: "deploy-1"}
+
+	attrs := LogAttrs(context.Background(), env)
+
+	got := attrsToMap(attrs)
+	want := map[string]string{
+		"railway.replica.id":     "replica-1",
+		"railway.replica.region": "us-west2",
+		"railway.service.name":   "web",
+		"railway.deployment.id":  "deploy-1",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("attribute %s = %q, want %q", k, got[k], v)
+		}
+	}
+	if _, ok := got["railway.request_id"]; ok {
+		t.Error("did not expect railway.request_id without request headers in context")
+	}
+}
+
+func TestLogAttrsWithHeaders(t *testing.T) {
+	env := Env{ServiceName: "web"}
+	ctx := withHeaders(context.Background(), Headers{
+		RailwayRequestID: "req-123",
+		RailwayEdge:      "edge-1",
+		RequestStart:     time.Now().Add(-10 * time.Millisecond),
+	})
+
+	got := attrsToMap(LogAttrs(ctx, env))
+	if got["railway.request_id"] != "req-123" {
+		t.Errorf("railway.request_id = %q, want %q", got["railway.request_id"], "req-123")
+	}
+	if got["railway.edge"] != "edge-1" {
+		t.Errorf("railway.edge = %q, want %q", got["railway.edge"], "edge-1")
+	}
+	if _, ok := got["railway.request_start_latency"]; !ok {
+		t.Error("expected railway.request_start_latency to be set")
+	}
+}
+
+func TestHCLogFieldsWithHeaders(t *testing.T) {
+	env := Env{ServiceName: "web"}
+	ctx := withHeaders(context.Background(), Headers{RailwayRequestID: "req-123"})
+
+	fields := HCLogFields(ctx, env)
+	if len(fields)%2 != 0 {
+		t.Fatalf("HCLogFields returned an odd number of elements: %d", len(fields))
+	}
+
+	got := make(map[string]interface{})
+	for i := 0; i < len(fields); i += 2 {
+		key, ok := fields[i].(string)
+		if !ok {
+			t.Fatalf("field %d key is not a string: %v", i, fields[i])
+		}
+		got[key] = fields[i+1]
+	}
+	if got["railway.service.name"] != "web" {
+		t.Errorf("railway.service.name = %v, want %q", got["railway.service.name"], "web")
+	}
+	if got["railway.request_id"] != "req-123" {
+		t.Errorf("railway.request_id = %v, want %q", got["railway.request_id"], "req-123")
+	}
+}
+
+func attrsToMap(attrs []slog.Attr) map[string]string {
+	m := make(map[string]string, len(attrs))
+	for _, a := range attrs {
+		m[a.Key] = a.Value.String()
+	}
+	return m
+}
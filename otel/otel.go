@@ -0,0 +1,51 @@
+// Package otel bridges Railway's request headers into OpenTelemetry
+// spans. It is kept separate from the base railway package so that
+// services which don't use OpenTelemetry aren't forced to pull in its
+// dependencies.
+package otel
+
+import (
+	"net/http"
+	"time"
+
+	railway "github.com/wbhob/go-railway"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName identifies the tracer used for spans created by Middleware.
+const TracerName = "github.com/wbhob/go-railway/otel"
+
+// Middleware wraps railway.Handler, additionally starting a span for
+// each request using X-Railway-Request-Id as the trace correlation id
+// and attributes describing the edge, replica, region, service and
+// deployment that served the request. env is typically loaded once at
+// startup via railway.Load.
+func Middleware(env railway.Env) func(http.Handler) http.Handler {
+	tracer := otel.Tracer(TracerName)
+	return func(next http.Handler) http.Handler {
+		base := railway.Handler(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers := railway.HeadersFromRequest(r)
+
+			ctx, span := tracer.Start(r.Context(), r.URL.Path,
+				trace.WithAttributes(
+					attribute.String("railway.request_id", headers.RailwayRequestID),
+					attribute.String("railway.edge", headers.RailwayEdge),
+					attribute.String("railway.replica.id", env.ReplicaID),
+					attribute.String("railway.replica.region", env.ReplicaRegion),
+					attribute.String("railway.service.name", env.ServiceName),
+					attribute.String("railway.deployment.id", env.DeploymentID),
+				),
+			)
+			defer span.End()
+
+			if !headers.RequestStart.IsZero() {
+				span.SetAttributes(attribute.Int64("railway.request_start_latency_ms", time.Since(headers.RequestStart).Milliseconds()))
+			}
+
+			base.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package otel
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	railway "github.com/wbhob/go-railway"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddlewareRecordsSpanAttributes(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	env := railway.Env{
+		ReplicaID:     "replica-1",
+		ReplicaRegion: "us-west2",
+		ServiceName:   "web",
+		DeploymentID:  "deploy-1",
+	}
+
+	var reachedNext bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reachedNext = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handler := Middleware(env)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	req.Header.Set(railway.HeaderRailwayRequestID, "req-123")
+	req.Header.Set(railway.HeaderRailwayEdge, "edge-1")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !reachedNext {
+		t.Fatal("Middleware did not delegate to next")
+	}
+	if err := tp.ForceFlush(req.Context()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+
+	if span.Name != "/widgets" {
+		t.Fatalf("span name = %q, want %q", span.Name, "/widgets")
+	}
+
+	got := make(map[string]string)
+	for _, kv := range span.Attributes {
+		got[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	want := map[string]string{
+		"railway.request_id":     "req-123",
+		"railway.edge":           "edge-1",
+		"railway.replica.id":     "replica-1",
+		"railway.replica.region": "us-west2",
+		"railway.service.name":   "web",
+		"railway.deployment.id":  "deploy-1",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("attribute %s = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestMiddlewareRecordsRequestStartLatency(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	handler := Middleware(railway.Env{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	start := time.Now().Add(-50 * time.Millisecond)
+	req.Header.Set(railway.HeaderRequestStart, strconv.FormatInt(start.UnixMilli(), 10))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if err := tp.ForceFlush(req.Context()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+
+	var found bool
+	for _, kv := range spans[0].Attributes {
+		if string(kv.Key) == "railway.request_start_latency_ms" {
+			found = true
+			if kv.Value.AsInt64() <= 0 {
+				t.Errorf("railway.request_start_latency_ms = %d, want > 0", kv.Value.AsInt64())
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected railway.request_start_latency_ms attribute to be set")
+	}
+}
@@ -0,0 +1,66 @@
+package private
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httputil"
+
+	railway "github.com/wbhob/go-railway"
+	"golang.org/x/net/http2"
+)
+
+// HTTPClient returns an *http.Client pre-wired to reach service over
+// Railway's private network: dialing is pinned to IPv6 via Dial, requests
+// are spoken over HTTP/2 cleartext (h2c, since there's no TLS on the
+// private network to negotiate ALPN with), and outgoing requests
+// propagate X-Railway-Request-Id from the request context (as set by
+// railway.Handler) so traces chain across services.
+func HTTPClient(service string) *http.Client {
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			port = addr
+		}
+		return Dial(ctx, service, port)
+	}
+
+	transport := &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return dial(ctx, network, addr)
+		},
+	}
+
+	return &http.Client{Transport: &propagatingTransport{base: transport}}
+}
+
+// propagatingTransport copies the Railway request id carried by the
+// outgoing request's context onto the outbound headers before
+// delegating to base.
+type propagatingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *propagatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if headers, ok := railway.HeadersFromContext(req.Context()); ok && headers.RailwayRequestID != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(railway.HeaderRailwayRequestID, headers.RailwayRequestID)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// ReverseProxy returns an *httputil.ReverseProxy that forwards requests
+// to service on port over Railway's private network, suitable for
+// building an internal gateway in front of several services.
+func ReverseProxy(service, port string) *httputil.ReverseProxy {
+	client := HTTPClient(service)
+	return &httputil.ReverseProxy{
+		Transport: client.Transport,
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.Out.URL.Scheme = "http"
+			r.Out.URL.Host = net.JoinHostPort(service+"."+Domain, port)
+		},
+	}
+}
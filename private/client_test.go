@@ -0,0 +1,80 @@
+package private
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"testing"
+
+	railway "github.com/wbhob/go-railway"
+)
+
+type fakeRoundTripper struct {
+	gotHeader string
+}
+
+func (f *fakeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.gotHeader = req.Header.Get(railway.HeaderRailwayRequestID)
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Header: make(http.Header)}, nil
+}
+
+// contextWithRailwayHeaders round-trips req through railway.Handler to
+// populate its context the same way an inbound server request would,
+// since Headers are stored under an unexported context key.
+func contextWithRailwayHeaders(req *http.Request) context.Context {
+	var ctx context.Context
+	railway.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), req)
+	return ctx
+}
+
+func TestPropagatingTransportCopiesRequestID(t *testing.T) {
+	fake := &fakeRoundTripper{}
+	transport := &propagatingTransport{base: fake}
+
+	inbound := httptest.NewRequest(http.MethodGet, "/", nil)
+	inbound.Header.Set(railway.HeaderRailwayRequestID, "req-123")
+	ctx := contextWithRailwayHeaders(inbound)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.railway.internal/", nil).WithContext(ctx)
+
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	if fake.gotHeader != "req-123" {
+		t.Fatalf("got header %q, want %q", fake.gotHeader, "req-123")
+	}
+}
+
+func TestPropagatingTransportLeavesHeaderUnsetWithoutContext(t *testing.T) {
+	fake := &fakeRoundTripper{}
+	transport := &propagatingTransport{base: fake}
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.railway.internal/", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	if fake.gotHeader != "" {
+		t.Fatalf("got header %q, want empty", fake.gotHeader)
+	}
+}
+
+func TestReverseProxyRewritesToPrivateNetwork(t *testing.T) {
+	rp := ReverseProxy("web", "8080")
+
+	in := httptest.NewRequest(http.MethodGet, "https://gateway.example.com/api", nil)
+	out := in.Clone(in.Context())
+	pr := &httputil.ProxyRequest{In: in, Out: out}
+
+	rp.Rewrite(pr)
+
+	if pr.Out.URL.Scheme != "http" {
+		t.Errorf("Scheme = %q, want %q", pr.Out.URL.Scheme, "http")
+	}
+	want := "web." + Domain + ":8080"
+	if pr.Out.URL.Host != want {
+		t.Errorf("Host = %q, want %q", pr.Out.URL.Host, want)
+	}
+}
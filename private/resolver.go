@@ -0,0 +1,111 @@
+// Package private provides a service-to-service networking client for
+// Railway's private network, which resolves <service>.railway.internal
+// over IPv6-only DNS. See
+// https://docs.railway.com/guides/private-networking for more details.
+package private
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Domain is the DNS suffix for Railway's private network.
+const Domain = "railway.internal"
+
+// ipLookuper is the subset of *net.Resolver LookupService needs, narrowed
+// so tests can substitute a fake DNS backend to exercise the retry loop
+// without real lookups.
+type ipLookuper interface {
+	LookupIP(ctx context.Context, network, host string) ([]net.IP, error)
+}
+
+// Resolver wraps net.Resolver, forcing AAAA/tcp6 lookups since Railway's
+// private network is IPv6-only, and retrying through the brief DNS
+// propagation window right after a service cold-starts.
+type Resolver struct {
+	resolver ipLookuper
+
+	// RetryInterval is how long to wait between lookup attempts. Defaults
+	// to 250ms.
+	RetryInterval time.Duration
+	// RetryTimeout bounds how long LookupService keeps retrying before
+	// giving up. Defaults to 10s, covering Railway's typical private DNS
+	// propagation window for a freshly started service.
+	RetryTimeout time.Duration
+}
+
+// NewResolver returns a Resolver configured for Railway's private
+// network.
+func NewResolver() *Resolver {
+	return &Resolver{
+		resolver:      &net.Resolver{PreferGo: true},
+		RetryInterval: 250 * time.Millisecond,
+		RetryTimeout:  10 * time.Second,
+	}
+}
+
+var defaultResolver = NewResolver()
+
+// LookupService resolves service.railway.internal to its IPv6 addresses,
+// retrying until RetryTimeout elapses to ride out DNS propagation right
+// after a cold start.
+func (r *Resolver) LookupService(ctx context.Context, service string) ([]net.IP, error) {
+	host := service + "." + Domain
+
+	ctx, cancel := context.WithTimeout(ctx, r.RetryTimeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		addrs, err := r.resolver.LookupIP(ctx, "ip6", host)
+		if err == nil && len(addrs) > 0 {
+			return addrs, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr == nil {
+				lastErr = fmt.Errorf("no AAAA records for %s", host)
+			}
+			return nil, fmt.Errorf("resolve %s: %w", host, lastErr)
+		case <-time.After(r.RetryInterval):
+		}
+	}
+}
+
+// Dial connects to service on Railway's private network over IPv6,
+// retrying while DNS propagates.
+func (r *Resolver) Dial(ctx context.Context, service, port string) (net.Conn, error) {
+	addrs, err := r.LookupService(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{}
+	var lastErr error
+	for _, addr := range addrs {
+		conn, err := dialer.DialContext(ctx, "tcp6", net.JoinHostPort(addr.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("dial %s.%s:%s: %w", service, Domain, port, lastErr)
+}
+
+// LookupService resolves service.railway.internal using the default
+// Resolver.
+func LookupService(ctx context.Context, service string) ([]net.IP, error) {
+	return defaultResolver.LookupService(ctx, service)
+}
+
+// Dial connects to service on Railway's private network over IPv6 using
+// the default Resolver.
+func Dial(ctx context.Context, service, port string) (net.Conn, error) {
+	return defaultResolver.Dial(ctx, service, port)
+}
@@ -0,0 +1,91 @@
+package private
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeLookuper answers the nth LookupIP call from responses[n], repeating
+// the last entry once exhausted.
+type fakeLookuper struct {
+	calls     int32
+	responses []func() ([]net.IP, error)
+}
+
+func (f *fakeLookuper) LookupIP(ctx context.Context, network, host string) ([]net.IP, error) {
+	n := atomic.AddInt32(&f.calls, 1) - 1
+	resp := f.responses[len(f.responses)-1]
+	if int(n) < len(f.responses) {
+		resp = f.responses[n]
+	}
+	return resp()
+}
+
+func TestLookupServiceRetriesUntilSuccess(t *testing.T) {
+	addr := net.ParseIP("fd00::1")
+	fake := &fakeLookuper{
+		responses: []func() ([]net.IP, error){
+			func() ([]net.IP, error) { return nil, errors.New("no such host") },
+			func() ([]net.IP, error) { return nil, errors.New("no such host") },
+			func() ([]net.IP, error) { return []net.IP{addr}, nil },
+		},
+	}
+	r := &Resolver{resolver: fake, RetryInterval: time.Millisecond, RetryTimeout: time.Second}
+
+	addrs, err := r.LookupService(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(addrs) != 1 || !addrs[0].Equal(addr) {
+		t.Fatalf("got %v, want [%v]", addrs, addr)
+	}
+	if got := atomic.LoadInt32(&fake.calls); got != 3 {
+		t.Fatalf("LookupIP called %d times, want 3", got)
+	}
+}
+
+func TestLookupServiceGivesUpAfterRetryTimeout(t *testing.T) {
+	fake := &fakeLookuper{
+		responses: []func() ([]net.IP, error){
+			func() ([]net.IP, error) { return nil, errors.New("no such host") },
+		},
+	}
+	r := &Resolver{resolver: fake, RetryInterval: 5 * time.Millisecond, RetryTimeout: 30 * time.Millisecond}
+
+	start := time.Now()
+	_, err := r.LookupService(context.Background(), "web")
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected an error once RetryTimeout elapses")
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("LookupService took %v, want it to give up close to RetryTimeout", elapsed)
+	}
+	if calls := atomic.LoadInt32(&fake.calls); calls < 2 {
+		t.Fatalf("LookupIP called %d times, want at least 2 attempts", calls)
+	}
+}
+
+func TestLookupServiceRetriesOnEmptyResult(t *testing.T) {
+	addr := net.ParseIP("fd00::2")
+	fake := &fakeLookuper{
+		responses: []func() ([]net.IP, error){
+			func() ([]net.IP, error) { return nil, nil }, // resolves but no AAAA records yet
+			func() ([]net.IP, error) { return []net.IP{addr}, nil },
+		},
+	}
+	r := &Resolver{resolver: fake, RetryInterval: time.Millisecond, RetryTimeout: time.Second}
+
+	addrs, err := r.LookupService(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(addrs) != 1 || !addrs[0].Equal(addr) {
+		t.Fatalf("got %v, want [%v]", addrs, addr)
+	}
+}
@@ -0,0 +1,177 @@
+package railway
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// drainedServer tracks the in-flight request count (connections
+// currently serving a request) for a single *http.Server registered
+// with a Drainer.
+type drainedServer struct {
+	srv    *http.Server
+	active int64
+}
+
+// Drainer coordinates graceful shutdown with Railway's zero-downtime deploy
+// semantics. On SIGTERM it flips readiness to failing so the edge stops
+// routing new traffic to this replica, waits out
+// Env.DeploymentOverlapSeconds to let the incoming replica warm up, then
+// shuts down every registered server with a deadline of
+// Env.DeploymentDrainingSeconds before Railway sends SIGKILL.
+type Drainer struct {
+	overlap  time.Duration
+	draining time.Duration
+	onDrain  func(inFlight int)
+
+	mu      sync.Mutex
+	servers []*drainedServer
+
+	ready atomic.Bool
+	once  sync.Once
+	done  chan struct{}
+}
+
+// NewDrainer builds a Drainer from the deployment timings in env.
+func NewDrainer(env Env) *Drainer {
+	d := &Drainer{
+		overlap:  time.Duration(env.DeploymentOverlapSeconds) * time.Second,
+		draining: time.Duration(env.DeploymentDrainingSeconds) * time.Second,
+		done:     make(chan struct{}),
+	}
+	d.ready.Store(true)
+	return d
+}
+
+// OnDrain sets a callback invoked periodically during shutdown with the
+// number of in-flight requests remaining on each registered server. It is
+// optional and intended for logging progress while draining.
+func (d *Drainer) OnDrain(fn func(inFlight int)) {
+	d.onDrain = fn
+}
+
+// Register ties srv's lifecycle to the drainer, chaining any ConnState
+// hook srv already has. Register may be called multiple times to drain
+// several servers (e.g. an HTTP server and a metrics server) from a
+// single SIGTERM.
+func (d *Drainer) Register(srv *http.Server) {
+	ds := &drainedServer{srv: srv}
+	prevConnState := srv.ConnState
+	var serving sync.Map // net.Conn -> struct{} while serving a request
+	srv.ConnState = func(conn net.Conn, state http.ConnState) {
+		switch state {
+		case http.StateActive:
+			if _, loaded := serving.LoadOrStore(conn, struct{}{}); !loaded {
+				atomic.AddInt64(&ds.active, 1)
+			}
+		case http.StateIdle, http.StateClosed, http.StateHijacked:
+			if _, loaded := serving.LoadAndDelete(conn); loaded {
+				atomic.AddInt64(&ds.active, -1)
+			}
+		}
+		if prevConnState != nil {
+			prevConnState(conn, state)
+		}
+	}
+
+	d.mu.Lock()
+	d.servers = append(d.servers, ds)
+	d.mu.Unlock()
+}
+
+// ReadyHandler returns an http.Handler for a readiness probe. It answers
+// 200 while the process is accepting traffic and 503 once shutdown has
+// begun, steering Railway's edge toward the replacement replica.
+func (d *Drainer) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !d.ready.Load() {
+			http.Error(w, "draining", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// Listen installs a SIGTERM handler and runs the drain sequence in the
+// background once it fires. Call Wait to block until the drain sequence
+// has finished.
+func (d *Drainer) Listen() {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGTERM)
+	go func() {
+		<-sig
+		d.drain()
+	}()
+}
+
+// Wait blocks until the drain sequence has completed, i.e. until every
+// registered server has shut down or its draining deadline has elapsed.
+func (d *Drainer) Wait() {
+	<-d.done
+}
+
+func (d *Drainer) drain() {
+	d.once.Do(func() {
+		d.ready.Store(false)
+
+		if d.overlap > 0 {
+			time.Sleep(d.overlap)
+		}
+
+		// DeploymentDrainingSeconds defaults to 0, meaning Railway sends
+		// SIGKILL almost immediately after SIGTERM. Shutdown must honor
+		// that on the Go side too: a <= 0 draining budget gives an
+		// already-expired deadline, so Shutdown closes the listeners and
+		// idle connections and returns right away instead of blocking
+		// forever on a slow client. Connections still serving a request
+		// are left open.
+		ctx, cancel := context.WithTimeout(context.Background(), d.draining)
+		defer cancel()
+
+		d.mu.Lock()
+		servers := append([]*drainedServer(nil), d.servers...)
+		d.mu.Unlock()
+
+		var wg sync.WaitGroup
+		for _, ds := range servers {
+			wg.Add(1)
+			go func(ds *drainedServer) {
+				defer wg.Done()
+				d.drainServer(ctx, ds)
+			}(ds)
+		}
+		wg.Wait()
+
+		close(d.done)
+	})
+}
+
+func (d *Drainer) drainServer(ctx context.Context, ds *drainedServer) {
+	stop := make(chan struct{})
+	if d.onDrain != nil {
+		go func() {
+			t := time.NewTicker(250 * time.Millisecond)
+			defer t.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-t.C:
+					d.onDrain(int(atomic.LoadInt64(&ds.active)))
+				}
+			}
+		}()
+	}
+	_ = ds.srv.Shutdown(ctx)
+	close(stop)
+	if d.onDrain != nil {
+		d.onDrain(int(atomic.LoadInt64(&ds.active)))
+	}
+}
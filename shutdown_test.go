@@ -0,0 +1,119 @@
+package railway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDrainerReadyHandlerFlipsOnDrain(t *testing.T) {
+	d := NewDrainer(Env{})
+
+	rec := httptest.NewRecorder()
+	d.ReadyHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ready before drain: got %d, want 200", rec.Code)
+	}
+
+	d.drain()
+
+	rec = httptest.NewRecorder()
+	d.ReadyHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("ready after drain: got %d, want 503", rec.Code)
+	}
+
+	select {
+	case <-d.done:
+	default:
+		t.Fatal("done channel not closed after drain")
+	}
+}
+
+func TestDrainerShutsDownRegisteredServers(t *testing.T) {
+	d := NewDrainer(Env{})
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	d.Register(srv.Config)
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET before drain: %v", err)
+	}
+	resp.Body.Close()
+
+	d.drain()
+	d.Wait()
+
+	if _, err := http.Get(srv.URL); err == nil {
+		t.Fatal("expected GET after drain to fail, server should be shut down")
+	}
+}
+
+func TestDrainerDrainReturnsPromptlyWithZeroDrainingSeconds(t *testing.T) {
+	// DeploymentDrainingSeconds defaults to 0, meaning Railway sends
+	// SIGKILL almost immediately. drain() must not block forever on a
+	// connection the handler never finishes, even then.
+	d := NewDrainer(Env{DeploymentDrainingSeconds: 0})
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-unblock
+	}))
+	d.Register(srv.Config)
+	srv.Start()
+	defer srv.Close()
+	defer close(unblock)
+
+	go func() {
+		_, _ = http.Get(srv.URL)
+	}()
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		d.drain()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("drain() hung on an in-flight connection despite a 0s draining budget")
+	}
+}
+
+func TestDrainerDrainIsIdempotent(t *testing.T) {
+	d := NewDrainer(Env{})
+	d.drain()
+	d.drain() // must not panic on the already-closed done channel
+	d.Wait()
+}
+
+func TestDrainerOnDrainReportsInFlight(t *testing.T) {
+	d := NewDrainer(Env{})
+
+	var calls int
+	d.OnDrain(func(inFlight int) { calls++ })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	d.Register(srv.Config)
+	defer srv.Close()
+
+	d.drain()
+	d.Wait()
+
+	if calls == 0 {
+		t.Fatal("expected OnDrain callback to run at least once (final report after Shutdown)")
+	}
+}